@@ -0,0 +1,11 @@
+package infra
+
+import "errors"
+
+// ErrWatchUnsupported is returned by Watch when the underlying store
+// doesn't support change-stream style subscriptions.
+var ErrWatchUnsupported = errors.New("infra: store does not support watch")
+
+// ErrReconnectUnsupported is returned by Reconnects when the underlying
+// store doesn't track connection health.
+var ErrReconnectUnsupported = errors.New("infra: store does not support reconnect notifications")
@@ -0,0 +1,25 @@
+package infra
+
+import "github.com/Kimbbakar/Cogman/infra/store"
+
+// reconnectNotifier is implemented by TaskStore backends that track
+// connection health and can notify callers of a state change (currently
+// only the Mongo store).
+type reconnectNotifier interface {
+	Reconnects() <-chan bool
+}
+
+// Reconnects returns a channel that receives the current connection
+// state (true = healthy) each time ts's backend observes a transition,
+// so callers can react to a failover instead of discovering it only
+// when the next operation errors. A transition observed while the
+// previous one is still unread is dropped. It returns ErrReconnectUnsupported if
+// ts isn't backed by a store that tracks connection health (e.g.
+// Postgres or the in-memory store used in tests).
+func Reconnects(ts store.TaskStore) (<-chan bool, error) {
+	r, ok := ts.(reconnectNotifier)
+	if !ok {
+		return nil, ErrReconnectUnsupported
+	}
+	return r.Reconnects(), nil
+}
@@ -0,0 +1,56 @@
+package store
+
+import (
+	"errors"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// duplicateKeyCode is the MongoDB error code for a unique-index
+// violation (E11000).
+const duplicateKeyCode = 11000
+
+var (
+	// ErrNotFound is returned by Update/UpdatePartial when the query
+	// matches no document, and by Get when no document exists.
+	ErrNotFound = errors.New("store: not found")
+
+	// ErrDuplicate is returned when a write violates a unique index.
+	ErrDuplicate = errors.New("store: duplicate key")
+
+	// ErrTransient is returned for errors a caller should retry, such
+	// as a primary failover mid-transaction.
+	ErrTransient = errors.New("store: transient error, retry")
+)
+
+// classify maps a raw Mongo driver error to one of the sentinel errors
+// above so callers can branch on failure mode instead of string- or
+// code-matching driver internals themselves. Errors it doesn't
+// recognize are returned unchanged.
+func classify(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return ErrNotFound
+	}
+
+	var we mongo.WriteException
+	if errors.As(err, &we) {
+		for _, werr := range we.WriteErrors {
+			if werr.Code == duplicateKeyCode {
+				return ErrDuplicate
+			}
+		}
+	}
+
+	var ce mongo.CommandError
+	if errors.As(err, &ce) {
+		if ce.HasErrorLabel("TransientTransactionError") || ce.HasErrorLabel("UnknownTransactionCommitResult") {
+			return ErrTransient
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,243 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+)
+
+// memoryStore is an in-memory TaskStore for unit tests. It round-trips
+// values through encoding/json so Create/Get/Update behave like a real
+// document store instead of aliasing caller structs.
+type memoryStore struct {
+	mu   sync.Mutex
+	docs []map[string]interface{}
+}
+
+// NewMemoryStore returns a TaskStore backed by an in-process slice of
+// documents, useful
+// for tests that don't want a live MongoDB or Postgres instance.
+func NewMemoryStore() TaskStore {
+	return &memoryStore{}
+}
+
+// memTxKey marks a context as already holding memoryStore's lock, set
+// by Transaction. sync.Mutex isn't reentrant, and the whole point of
+// Transaction is to let its callback call back into Create/Get/Update
+// on the same store, so every method below checks this instead of
+// unconditionally locking.
+type memTxKey struct{}
+
+func inMemTx(ctx context.Context) bool {
+	v, _ := ctx.Value(memTxKey{}).(bool)
+	return v
+}
+
+func toDoc(t interface{}) (map[string]interface{}, error) {
+	b, err := json.Marshal(t)
+	if err != nil {
+		return nil, err
+	}
+	doc := map[string]interface{}{}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+func matches(doc map[string]interface{}, q Query) bool {
+	for _, p := range q {
+		v, ok := doc[p.Field]
+		if !ok {
+			return false
+		}
+		switch p.Op {
+		case OpNe:
+			if v == p.Value {
+				return false
+			}
+		case OpIn:
+			vals, ok := p.Value.([]interface{})
+			if !ok {
+				return false
+			}
+			found := false
+			for _, want := range vals {
+				if v == want {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		default:
+			// OpEq and the ordering operators are compared for equality
+			// only; the in-memory store is meant for simple unit tests,
+			// not for exercising range queries.
+			if v != p.Value {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// memoryResult adapts a decoded document to Result.
+type memoryResult struct {
+	doc map[string]interface{}
+}
+
+func (r *memoryResult) Decode(v interface{}) error {
+	b, err := json.Marshal(r.doc)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+// memoryCursor adapts a slice of documents to Cursor.
+type memoryCursor struct {
+	docs []map[string]interface{}
+	pos  int
+}
+
+func (c *memoryCursor) Next() bool {
+	if c.pos >= len(c.docs) {
+		return false
+	}
+	c.pos++
+	return true
+}
+
+func (c *memoryCursor) Decode(v interface{}) error {
+	b, err := json.Marshal(c.docs[c.pos-1])
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+func (c *memoryCursor) Close() error { return nil }
+func (c *memoryCursor) Err() error   { return nil }
+
+func (m *memoryStore) Create(ctx context.Context, t interface{}) error {
+	doc, err := toDoc(t)
+	if err != nil {
+		return err
+	}
+
+	if !inMemTx(ctx) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+	}
+	m.docs = append(m.docs, doc)
+	return nil
+}
+
+func (m *memoryStore) Get(ctx context.Context, q Query) (Result, error) {
+	if !inMemTx(ctx) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+	}
+
+	for _, doc := range m.docs {
+		if matches(doc, q) {
+			return &memoryResult{doc: doc}, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (m *memoryStore) Update(ctx context.Context, q Query, val interface{}) error {
+	doc, err := toDoc(val)
+	if err != nil {
+		return err
+	}
+
+	if !inMemTx(ctx) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+	}
+
+	for i, d := range m.docs {
+		if matches(d, q) {
+			m.docs[i] = doc
+			return nil
+		}
+	}
+	return ErrNotFound
+}
+
+func (m *memoryStore) UpdatePartial(ctx context.Context, q Query, val interface{}) error {
+	patch, err := toDoc(val)
+	if err != nil {
+		return err
+	}
+
+	if !inMemTx(ctx) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+	}
+
+	for _, d := range m.docs {
+		if matches(d, q) {
+			for k, v := range patch {
+				d[k] = v
+			}
+			return nil
+		}
+	}
+	return ErrNotFound
+}
+
+func (m *memoryStore) List(ctx context.Context, q Query, skip, limit int) (Cursor, error) {
+	if !inMemTx(ctx) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+	}
+
+	matched := []map[string]interface{}{}
+	for _, d := range m.docs {
+		if matches(d, q) {
+			matched = append(matched, d)
+		}
+	}
+
+	if skip > len(matched) {
+		skip = len(matched)
+	}
+	matched = matched[skip:]
+
+	if limit > 0 && limit < len(matched) {
+		matched = matched[:limit]
+	}
+
+	return &memoryCursor{docs: matched}, nil
+}
+
+func (m *memoryStore) EnsureIndices(ctx context.Context, indices []Index) error {
+	return nil
+}
+
+func (m *memoryStore) SetTTL(ctx context.Context) error {
+	return nil
+}
+
+// Transaction holds the store's lock for fn's whole duration so it
+// behaves atomically with respect to other callers, and marks ctx so
+// fn can call back into Create/Get/Update/etc. on the same store
+// without those methods trying to re-acquire the (non-reentrant) lock.
+func (m *memoryStore) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return fn(context.WithValue(ctx, memTxKey{}, true))
+}
+
+func (m *memoryStore) Ping(ctx context.Context) error {
+	return nil
+}
+
+func (m *memoryStore) Close() error {
+	return nil
+}
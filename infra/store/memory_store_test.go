@@ -0,0 +1,139 @@
+package store
+
+import (
+	"context"
+	"testing"
+)
+
+type memTask struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+	Prio   int    `json:"prio"`
+}
+
+func TestMemoryStoreCreateGet(t *testing.T) {
+	m := NewMemoryStore()
+	ctx := context.Background()
+
+	if err := m.Create(ctx, memTask{ID: "1", Status: "pending"}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	res, err := m.Get(ctx, Query{Eq("id", "1")})
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	var got memTask
+	if err := res.Decode(&got); err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if got.Status != "pending" {
+		t.Fatalf("got status %q, want %q", got.Status, "pending")
+	}
+}
+
+func TestMemoryStoreGetNotFound(t *testing.T) {
+	m := NewMemoryStore()
+	ctx := context.Background()
+
+	if _, err := m.Get(ctx, Query{Eq("id", "missing")}); err != ErrNotFound {
+		t.Fatalf("got err %v, want ErrNotFound", err)
+	}
+}
+
+func TestMemoryStoreUpdatePartial(t *testing.T) {
+	m := NewMemoryStore()
+	ctx := context.Background()
+
+	if err := m.Create(ctx, memTask{ID: "1", Status: "pending"}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if err := m.UpdatePartial(ctx, Query{Eq("id", "1")}, map[string]interface{}{"status": "done"}); err != nil {
+		t.Fatalf("UpdatePartial: %v", err)
+	}
+
+	res, err := m.Get(ctx, Query{Eq("id", "1")})
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	var got memTask
+	if err := res.Decode(&got); err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if got.Status != "done" {
+		t.Fatalf("got status %q, want %q", got.Status, "done")
+	}
+}
+
+func TestMemoryStoreQueryMatching(t *testing.T) {
+	m := NewMemoryStore()
+	ctx := context.Background()
+
+	for _, task := range []memTask{
+		{ID: "1", Status: "pending"},
+		{ID: "2", Status: "done"},
+		{ID: "3", Status: "pending"},
+	} {
+		if err := m.Create(ctx, task); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+
+	cur, err := m.List(ctx, Query{Eq("status", "pending")}, 0, 0)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	defer cur.Close()
+
+	var ids []string
+	for cur.Next() {
+		var got memTask
+		if err := cur.Decode(&got); err != nil {
+			t.Fatalf("Decode: %v", err)
+		}
+		ids = append(ids, got.ID)
+	}
+	if len(ids) != 2 {
+		t.Fatalf("got %d matches, want 2: %v", len(ids), ids)
+	}
+}
+
+func TestMemoryStoreTransactionCommits(t *testing.T) {
+	m := NewMemoryStore()
+	ctx := context.Background()
+
+	err := m.Transaction(ctx, func(ctx context.Context) error {
+		if err := m.Create(ctx, memTask{ID: "1", Status: "pending"}); err != nil {
+			return err
+		}
+		return m.UpdatePartial(ctx, Query{Eq("id", "1")}, map[string]interface{}{"status": "done"})
+	})
+	if err != nil {
+		t.Fatalf("Transaction: %v", err)
+	}
+
+	res, err := m.Get(ctx, Query{Eq("id", "1")})
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	var got memTask
+	if err := res.Decode(&got); err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if got.Status != "done" {
+		t.Fatalf("got status %q, want %q", got.Status, "done")
+	}
+}
+
+func TestMemoryStoreTransactionPropagatesError(t *testing.T) {
+	m := NewMemoryStore()
+	ctx := context.Background()
+
+	err := m.Transaction(ctx, func(ctx context.Context) error {
+		return m.Update(ctx, Query{Eq("id", "missing")}, memTask{})
+	})
+	if err != ErrNotFound {
+		t.Fatalf("got err %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,166 @@
+package store
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoOptions controls the connection lifecycle: timeouts, the
+// background health-check loop, and TLS/auth. A stalled primary should
+// never hang a caller indefinitely, so every timeout here has a
+// non-zero default.
+type MongoOptions struct {
+	ConnectTimeout      time.Duration
+	OpTimeout           time.Duration
+	HealthCheckInterval time.Duration
+	HealthCheckRetries  int
+	TLSConfig           *tls.Config
+	Credential          *options.Credential
+	BSONOptions         *options.BSONOptions
+}
+
+const (
+	defaultConnectTimeout      = 10 * time.Second
+	defaultOpTimeout           = 10 * time.Second
+	defaultHealthCheckInterval = 30 * time.Second
+	defaultHealthCheckRetries  = 3
+)
+
+func (o MongoOptions) connectTimeout() time.Duration {
+	if o.ConnectTimeout > 0 {
+		return o.ConnectTimeout
+	}
+	return defaultConnectTimeout
+}
+
+func (o MongoOptions) opTimeout() time.Duration {
+	if o.OpTimeout > 0 {
+		return o.OpTimeout
+	}
+	return defaultOpTimeout
+}
+
+func (o MongoOptions) healthCheckInterval() time.Duration {
+	if o.HealthCheckInterval > 0 {
+		return o.HealthCheckInterval
+	}
+	return defaultHealthCheckInterval
+}
+
+func (o MongoOptions) healthCheckRetries() int {
+	if o.HealthCheckRetries > 0 {
+		return o.HealthCheckRetries
+	}
+	return defaultHealthCheckRetries
+}
+
+func (o MongoOptions) apply(opts *options.ClientOptions) {
+	if o.TLSConfig != nil {
+		opts.SetTLSConfig(o.TLSConfig)
+	}
+	if o.Credential != nil {
+		opts.SetAuth(*o.Credential)
+	}
+	if o.BSONOptions != nil {
+		opts.SetBSONOptions(o.BSONOptions)
+	}
+}
+
+// LoadTLSConfig builds a tls.Config that trusts the CA certificate at
+// caFile, for connecting to MongoDB Atlas or an enterprise cluster that
+// requires a custom CA. Combine the result with TLSConfig.Certificates
+// for x509 client authentication.
+func LoadTLSConfig(caFile string) (*tls.Config, error) {
+	pem, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("store: no certificates found in %s", caFile)
+	}
+
+	return &tls.Config{RootCAs: pool}, nil
+}
+
+// opContext derives a timeout-bound context for a single operation,
+// since the bare context.WithCancel the client used to create had no
+// deadline and could hang forever against a stalled primary.
+func (m *mongoStore) opContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, m.opts.opTimeout())
+}
+
+// connected reports the health-check loop's last observed connection
+// state. It starts optimistic (true) until the first check runs.
+func (m *mongoStore) connected() bool {
+	return atomic.LoadInt32(&m.isConnected) == 1
+}
+
+func (m *mongoStore) setConnected(v bool) {
+	var i int32
+	if v {
+		i = 1
+	}
+	if atomic.SwapInt32(&m.isConnected, i) != i {
+		select {
+		case m.reconnects <- v:
+		default:
+			// Best-effort: don't block health-check ticks on a slow
+			// or absent consumer of reconnect events.
+		}
+	}
+}
+
+// Reconnects returns a channel that receives the current connection
+// state (true = healthy) each time the background health check
+// observes a transition, so callers can react to a failover instead of
+// discovering it only when the next operation errors. The channel holds
+// one pending state; a transition observed while it is still unread is
+// dropped.
+func (m *mongoStore) Reconnects() <-chan bool {
+	return m.reconnects
+}
+
+// healthCheckLoop pings on HealthCheckInterval and flips isConnected
+// only after HealthCheckRetries consecutive failures (or successes),
+// mirroring servicecomb-service-center's Mongo client so a single
+// blip doesn't flap the connected state.
+func (m *mongoStore) healthCheckLoop(ctx context.Context) {
+	ticker := time.NewTicker(m.opts.healthCheckInterval())
+	defer ticker.Stop()
+
+	var failures, successes int
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			pingCtx, cancel := context.WithTimeout(ctx, m.opts.opTimeout())
+			err := m.mcl.Ping(pingCtx, nil)
+			cancel()
+
+			if err != nil {
+				failures++
+				successes = 0
+				if failures >= m.opts.healthCheckRetries() {
+					m.setConnected(false)
+				}
+				continue
+			}
+
+			failures = 0
+			successes++
+			if successes >= m.opts.healthCheckRetries() || m.connected() {
+				m.setConnected(true)
+			}
+		}
+	}
+}
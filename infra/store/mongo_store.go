@@ -0,0 +1,416 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+const (
+	defaultDatabase   = "cogman"
+	defaultCollection = "tasks"
+)
+
+// MongoConfig controls which database/collection a mongoStore talks to.
+// CollectionResolver takes precedence over Collection when set, letting
+// a multi-tenant caller route each request to its own collection based
+// on ctx (e.g. a tenant ID stashed there by middleware).
+type MongoConfig struct {
+	Database           string
+	Collection         string
+	CollectionResolver func(ctx context.Context) string
+
+	// RetryPolicy governs retries of transient errors (see classify).
+	// The zero value falls back to DefaultRetryPolicy.
+	RetryPolicy RetryPolicy
+}
+
+func (c MongoConfig) database() string {
+	if c.Database != "" {
+		return c.Database
+	}
+	return defaultDatabase
+}
+
+func (c MongoConfig) collection(ctx context.Context) string {
+	if c.CollectionResolver != nil {
+		return c.CollectionResolver(ctx)
+	}
+	if c.Collection != "" {
+		return c.Collection
+	}
+	return defaultCollection
+}
+
+// mongoStore is the MongoDB-backed TaskStore implementation.
+type mongoStore struct {
+	url    string
+	expDur int32
+	cfg    MongoConfig
+	retry  RetryPolicy
+	opts   MongoOptions
+	mcl    *mongo.Client
+
+	isConnected int32 // atomic bool, flipped by healthCheckLoop
+	reconnects  chan bool
+	stopHealth  context.CancelFunc
+}
+
+// NewMongoStore returns a TaskStore backed by MongoDB, using the
+// default "cogman"/"tasks" database and collection.
+func NewMongoStore(url string, ttl time.Duration) (TaskStore, error) {
+	return NewMongoStoreWithConfig(url, ttl, MongoConfig{})
+}
+
+// NewMongoStoreWithConfig returns a TaskStore backed by MongoDB, routed
+// to the database/collection described by cfg, with default connection
+// lifecycle options (see NewMongoStoreWithOptions to customize those).
+func NewMongoStoreWithConfig(url string, ttl time.Duration, cfg MongoConfig) (TaskStore, error) {
+	return NewMongoStoreWithOptions(url, ttl, cfg, MongoOptions{})
+}
+
+// NewMongoStoreWithOptions returns a TaskStore backed by MongoDB,
+// applying opts' connect/op timeouts and TLS/auth settings, and starts
+// a background goroutine that pings the cluster every
+// HealthCheckInterval to track connection health.
+func NewMongoStoreWithOptions(url string, ttl time.Duration, cfg MongoConfig, opts MongoOptions) (TaskStore, error) {
+	clientOpts := options.Client().ApplyURI(url)
+	opts.apply(clientOpts)
+
+	connectCtx, cancel := context.WithTimeout(context.Background(), opts.connectTimeout())
+	defer cancel()
+
+	conn, err := mongo.Connect(connectCtx, clientOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	healthCtx, stopHealth := context.WithCancel(context.Background())
+	m := &mongoStore{
+		url:         url,
+		expDur:      int32(ttl.Seconds()),
+		cfg:         cfg,
+		retry:       cfg.RetryPolicy.orDefault(),
+		opts:        opts,
+		mcl:         conn,
+		isConnected: 1,
+		reconnects:  make(chan bool, 1),
+		stopHealth:  stopHealth,
+	}
+
+	go m.healthCheckLoop(healthCtx)
+
+	return m, nil
+}
+
+// Init pre-creates indices on each of the given collections, which is
+// useful when a deployment knows its full set of tenant collections up
+// front and wants them ready before the first write.
+func Init(ctx context.Context, mcl *mongo.Client, cfg MongoConfig, collections []string, indices []Index) error {
+	models := make([]mongo.IndexModel, 0, len(indices))
+	for _, ind := range indices {
+		models = append(models, ind.model())
+	}
+
+	for _, coll := range collections {
+		col := mcl.Database(cfg.database()).Collection(coll)
+		if len(models) > 0 {
+			if _, err := col.Indexes().CreateMany(ctx, models); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *mongoStore) getCollection(ctx context.Context) *mongo.Collection {
+	return m.mcl.Database(m.cfg.database()).Collection(m.cfg.collection(ctx))
+}
+
+// toBSON translates a backend-neutral Query into the bson.M filter the
+// Mongo driver expects.
+func toBSON(q Query) bson.M {
+	f := bson.M{}
+	for _, p := range q {
+		switch p.Op {
+		case OpNe:
+			f[p.Field] = bson.M{"$ne": p.Value}
+		case OpGt:
+			f[p.Field] = bson.M{"$gt": p.Value}
+		case OpGte:
+			f[p.Field] = bson.M{"$gte": p.Value}
+		case OpLt:
+			f[p.Field] = bson.M{"$lt": p.Value}
+		case OpLte:
+			f[p.Field] = bson.M{"$lte": p.Value}
+		case OpIn:
+			f[p.Field] = bson.M{"$in": p.Value}
+		default:
+			f[p.Field] = p.Value
+		}
+	}
+	return f
+}
+
+func (i Index) model() mongo.IndexModel {
+	keys := bson.D{}
+	for _, k := range i.Keys {
+		d := 1
+		if k.Desc {
+			d = -1
+		}
+		keys = append(keys, bson.E{Key: k.Key, Value: d})
+	}
+
+	opts := &options.IndexOptions{}
+	if i.Name != "" {
+		opts.SetName(i.Name)
+	}
+	opts.SetSparse(i.Sparse)
+	opts.SetUnique(i.Unique)
+
+	return mongo.IndexModel{
+		Keys:    keys,
+		Options: opts,
+	}
+}
+
+// mongoResult adapts *mongo.SingleResult to Result.
+type mongoResult struct {
+	res *mongo.SingleResult
+}
+
+func (r *mongoResult) Decode(v interface{}) error {
+	return r.res.Decode(v)
+}
+
+// mongoCursor adapts *mongo.Cursor to Cursor, binding it to the context
+// it was created with since the neutral interface carries none.
+type mongoCursor struct {
+	ctx    context.Context
+	cursor *mongo.Cursor
+}
+
+func (c *mongoCursor) Next() bool {
+	return c.cursor.Next(c.ctx)
+}
+
+func (c *mongoCursor) Decode(v interface{}) error {
+	return c.cursor.Decode(v)
+}
+
+func (c *mongoCursor) Close() error {
+	return c.cursor.Close(c.ctx)
+}
+
+func (c *mongoCursor) Err() error {
+	return c.cursor.Err()
+}
+
+// Ping check the mongo connection status
+func (m *mongoStore) Ping(ctx context.Context) error {
+	ctx, cancel := m.opContext(ctx)
+	defer cancel()
+
+	return classify(m.mcl.Ping(ctx, readpref.Primary()))
+}
+
+// SetTTL for mongo object
+func (m *mongoStore) SetTTL(ctx context.Context) error {
+	return m.retry.retry(ctx, func() error {
+		ctx, cancel := m.opContext(ctx)
+		defer cancel()
+
+		col := m.getCollection(ctx)
+
+		col.Indexes().DropOne(ctx, "TTL")
+
+		opts := &options.IndexOptions{}
+		opts.SetName("TTL")
+		opts.SetExpireAfterSeconds(m.expDur)
+
+		model := mongo.IndexModel{
+			Keys: bson.D{
+				bson.E{Key: "created_at", Value: 1},
+			},
+			Options: opts,
+		}
+
+		_, err := col.Indexes().CreateOne(ctx, model)
+		return classify(err)
+	})
+}
+
+// EnsureIndices ensure mongo index list
+func (m *mongoStore) EnsureIndices(ctx context.Context, indices []Index) error {
+	return m.retry.retry(ctx, func() error {
+		ctx, cancel := m.opContext(ctx)
+		defer cancel()
+
+		col := m.getCollection(ctx)
+
+		models := []mongo.IndexModel{}
+		for _, ind := range indices {
+			models = append(models, ind.model())
+		}
+
+		_, err := col.Indexes().CreateMany(ctx, models)
+		return classify(err)
+	})
+}
+
+// Close stops the health-check loop and closes the mongo connection.
+func (m *mongoStore) Close() error {
+	m.stopHealth()
+	return m.mcl.Disconnect(context.Background())
+}
+
+// Get return a single object based on query parameter
+func (m *mongoStore) Get(ctx context.Context, q Query) (Result, error) {
+	var result Result
+
+	err := m.retry.retry(ctx, func() error {
+		ctx, cancel := m.opContext(ctx)
+		defer cancel()
+
+		col := m.getCollection(ctx)
+
+		resp := col.FindOne(ctx, toBSON(q))
+		if resp.Err() != nil {
+			return classify(resp.Err())
+		}
+
+		result = &mongoResult{res: resp}
+		return nil
+	})
+
+	return result, err
+}
+
+// Create create a object
+func (m *mongoStore) Create(ctx context.Context, t interface{}) error {
+	return m.retry.retry(ctx, func() error {
+		ctx, cancel := m.opContext(ctx)
+		defer cancel()
+
+		col := m.getCollection(ctx)
+
+		_, err := col.InsertOne(ctx, t)
+		return classify(err)
+	})
+}
+
+// Update replaces the matched document wholesale via ReplaceOne,
+// matching postgresStore.Update and memoryStore.Update's overwrite:
+// any field present in the old document but absent from val does not
+// survive the call.
+func (m *mongoStore) Update(ctx context.Context, q Query, val interface{}) error {
+	return m.retry.retry(ctx, func() error {
+		ctx, cancel := m.opContext(ctx)
+		defer cancel()
+
+		col := m.getCollection(ctx)
+
+		resp, err := col.ReplaceOne(ctx, toBSON(q), val)
+		if err != nil {
+			return classify(err)
+		}
+		if resp.MatchedCount == 0 {
+			return ErrNotFound
+		}
+
+		return nil
+	})
+}
+
+// UpdatePartial merges val's fields into the matched document via
+// $set, matching postgresStore.UpdatePartial's JSONB merge and
+// memoryStore.UpdatePartial: fields not present in val are left
+// untouched. val is a flat field map/struct, not a raw Mongo update
+// document — callers needing operators other than $set have no
+// portable equivalent on the other backends and should use Get+Update.
+func (m *mongoStore) UpdatePartial(ctx context.Context, q Query, val interface{}) error {
+	return m.retry.retry(ctx, func() error {
+		ctx, cancel := m.opContext(ctx)
+		defer cancel()
+
+		col := m.getCollection(ctx)
+
+		fields, err := bson.Marshal(val)
+		if err != nil {
+			return err
+		}
+		var patch bson.M
+		if err := bson.Unmarshal(fields, &patch); err != nil {
+			return err
+		}
+
+		resp, err := col.UpdateOne(ctx, toBSON(q), bson.M{"$set": patch})
+		if err != nil {
+			return classify(err)
+		}
+		if resp.MatchedCount == 0 {
+			return ErrNotFound
+		}
+
+		return nil
+	})
+}
+
+// List return a cursor of objects based on query parameter
+func (m *mongoStore) List(ctx context.Context, q Query, skip, limit int) (Cursor, error) {
+	var result Cursor
+
+	err := m.retry.retry(ctx, func() error {
+		col := m.getCollection(ctx)
+
+		// Only the initial round trip (server selection + sending the
+		// query) is bounded by opTimeout; the cursor keeps the caller's
+		// ctx for iteration since it's meant to outlive this call.
+		findCtx, cancel := m.opContext(ctx)
+		defer cancel()
+
+		opt := options.Find().SetSkip(int64(skip)).SetLimit(int64(limit))
+		cursor, err := col.Find(findCtx, toBSON(q), opt)
+		if err != nil {
+			return classify(err)
+		}
+		if cursor.Err() != nil {
+			return classify(cursor.Err())
+		}
+
+		result = &mongoCursor{ctx: ctx, cursor: cursor}
+		return nil
+	})
+
+	return result, err
+}
+
+// Transaction runs fn inside a Mongo session started with UseSession,
+// which binds the session to ctx for the duration of the callback
+// instead of tracking it in a caller-keyed map. This is goroutine-safe
+// and cannot leak a session if the caller never calls a matching
+// commit, since UseSession always ends the session on return. The
+// whole callback is retried on ErrTransient, per Mongo's recommended
+// transaction pattern.
+func (m *mongoStore) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return m.retry.retry(ctx, func() error {
+		return classify(m.mcl.UseSession(ctx, func(sctx mongo.SessionContext) error {
+			if err := sctx.StartTransaction(); err != nil {
+				return err
+			}
+
+			if err := fn(sctx); err != nil {
+				_ = sctx.AbortTransaction(sctx)
+				return err
+			}
+
+			return sctx.CommitTransaction(sctx)
+		}))
+	})
+}
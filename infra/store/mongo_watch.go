@@ -0,0 +1,78 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// TaskEvent is a single change-stream notification for a task document.
+type TaskEvent struct {
+	OperationType string
+	TaskID        interface{}
+	FullDocument  bson.M
+	ResumeToken   bson.Raw
+}
+
+// changeStreamEvent mirrors the subset of a Mongo change-stream
+// document Watch needs to decode into a TaskEvent.
+type changeStreamEvent struct {
+	OperationType string `bson:"operationType"`
+	DocumentKey   bson.M `bson:"documentKey"`
+	FullDocument  bson.M `bson:"fullDocument"`
+}
+
+// Watch opens a change stream on the task collection and streams
+// decoded TaskEvents to the returned channel until ctx is cancelled or
+// the stream errors, at which point the channel is closed. This is
+// Mongo-specific (change streams have no Postgres/memory equivalent),
+// so it lives on mongoStore rather than the neutral TaskStore
+// interface; callers type-assert to use it.
+//
+// Passing a non-nil resumeToken resumes the stream after a reconnect
+// instead of re-delivering already-seen events.
+func (m *mongoStore) Watch(ctx context.Context, pipeline []bson.D, resumeToken bson.Raw) (<-chan TaskEvent, error) {
+	col := m.getCollection(ctx)
+
+	opts := options.ChangeStream().
+		SetFullDocument(options.UpdateLookup).
+		SetMaxAwaitTime(2 * time.Second)
+	if resumeToken != nil {
+		opts.SetResumeAfter(resumeToken)
+	}
+
+	stream, err := col.Watch(ctx, pipeline, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan TaskEvent)
+	go func() {
+		defer close(events)
+		defer stream.Close(ctx)
+
+		for stream.Next(ctx) {
+			var raw changeStreamEvent
+			if err := stream.Decode(&raw); err != nil {
+				continue
+			}
+
+			event := TaskEvent{
+				OperationType: raw.OperationType,
+				TaskID:        raw.DocumentKey["_id"],
+				FullDocument:  raw.FullDocument,
+				ResumeToken:   stream.ResumeToken(),
+			}
+
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events, nil
+}
@@ -0,0 +1,379 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+const postgresTable = "tasks"
+
+// postgresStore is a Postgres-backed TaskStore. Documents are stored as
+// a single JSONB column, which keeps it schema-compatible with the
+// Mongo store without requiring a migration per task field.
+type postgresStore struct {
+	pool *pgxpool.Pool
+}
+
+// pgQuerier is the subset of *pgxpool.Pool and pgx.Tx that CRUD methods
+// need, so they can run against either depending on whether ctx carries
+// a transaction.
+type pgQuerier interface {
+	Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
+
+// txKey is the context key Transaction stashes its pgx.Tx under, so
+// every CRUD method called from inside a Transaction callback runs
+// against that transaction instead of the bare pool.
+type txKey struct{}
+
+// querier returns the pgx.Tx bound to ctx by Transaction, or the pool
+// if ctx carries none.
+func (p *postgresStore) querier(ctx context.Context) pgQuerier {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx
+	}
+	return p.pool
+}
+
+// NewPostgresStore connects to Postgres at dsn and returns a TaskStore
+// backed by a JSONB "tasks" table, creating it if it doesn't exist.
+func NewPostgresStore(dsn string) (TaskStore, error) {
+	pool, err := pgxpool.Connect(context.Background(), dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = pool.Exec(context.Background(), fmt.Sprintf(
+		`CREATE TABLE IF NOT EXISTS %s (id SERIAL PRIMARY KEY, doc JSONB NOT NULL)`,
+		postgresTable,
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	return &postgresStore{pool: pool}, nil
+}
+
+// identifierRe allowlists the characters valid in a Postgres identifier
+// without quoting, used to validate field/index names that end up
+// interpolated into SQL (they can't be bound as parameters).
+var identifierRe = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+func validateIdentifier(name string) error {
+	if !identifierRe.MatchString(name) {
+		return fmt.Errorf("store: invalid identifier %q", name)
+	}
+	return nil
+}
+
+// numericCast reports whether value's underlying Go kind is numeric, in
+// which case doc->>field (always text) must be cast to numeric before
+// an ordering comparison, or "10" < "9" lexically.
+func numericCast(value interface{}) bool {
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// toStringSlice converts a slice-typed OpIn value to []string so it can
+// be bound as a single text[] parameter for "= ANY($n)", instead of
+// stringifying the whole slice into one lexical value.
+func toStringSlice(value interface{}) ([]string, error) {
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("store: OpIn value must be a slice, got %T", value)
+	}
+
+	out := make([]string, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		out[i] = fmt.Sprintf("%v", v.Index(i).Interface())
+	}
+	return out, nil
+}
+
+// toWhere renders a Query as a parameterized SQL WHERE clause over the
+// doc JSONB column, e.g. doc->>'status' = $1. Field names are
+// allowlisted since they're interpolated into the query text; values
+// are always bound as real parameters, never string-formatted into it.
+func toWhere(q Query, startAt int) (string, []interface{}, error) {
+	if len(q) == 0 {
+		return "", nil, nil
+	}
+
+	clauses := make([]string, 0, len(q))
+	args := make([]interface{}, 0, len(q))
+	for _, p := range q {
+		if err := validateIdentifier(p.Field); err != nil {
+			return "", nil, err
+		}
+		idx := startAt + len(args)
+
+		if p.Op == OpIn {
+			vals, err := toStringSlice(p.Value)
+			if err != nil {
+				return "", nil, err
+			}
+			clauses = append(clauses, fmt.Sprintf("doc->>'%s' = ANY($%d)", p.Field, idx))
+			args = append(args, vals)
+			continue
+		}
+
+		field := fmt.Sprintf("doc->>'%s'", p.Field)
+		if numericCast(p.Value) {
+			field = fmt.Sprintf("(%s)::numeric", field)
+		}
+
+		switch p.Op {
+		case OpNe:
+			clauses = append(clauses, fmt.Sprintf("%s != $%d", field, idx))
+		case OpGt:
+			clauses = append(clauses, fmt.Sprintf("%s > $%d", field, idx))
+		case OpGte:
+			clauses = append(clauses, fmt.Sprintf("%s >= $%d", field, idx))
+		case OpLt:
+			clauses = append(clauses, fmt.Sprintf("%s < $%d", field, idx))
+		case OpLte:
+			clauses = append(clauses, fmt.Sprintf("%s <= $%d", field, idx))
+		default:
+			clauses = append(clauses, fmt.Sprintf("%s = $%d", field, idx))
+		}
+		args = append(args, p.Value)
+	}
+
+	return " WHERE " + strings.Join(clauses, " AND "), args, nil
+}
+
+// postgresResult adapts a decoded JSONB row to Result.
+type postgresResult struct {
+	raw []byte
+}
+
+func (r *postgresResult) Decode(v interface{}) error {
+	return json.Unmarshal(r.raw, v)
+}
+
+// postgresCursor adapts pgx.Rows to Cursor.
+type postgresCursor struct {
+	rows pgx.Rows
+	raw  []byte
+}
+
+func (c *postgresCursor) Next() bool {
+	if !c.rows.Next() {
+		return false
+	}
+	return c.rows.Scan(&c.raw) == nil
+}
+
+func (c *postgresCursor) Decode(v interface{}) error {
+	return json.Unmarshal(c.raw, v)
+}
+
+func (c *postgresCursor) Close() error {
+	c.rows.Close()
+	return nil
+}
+
+func (c *postgresCursor) Err() error {
+	return c.rows.Err()
+}
+
+func (p *postgresStore) Create(ctx context.Context, t interface{}) error {
+	doc, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+
+	_, err = p.querier(ctx).Exec(ctx,
+		fmt.Sprintf("INSERT INTO %s (doc) VALUES ($1)", postgresTable), doc)
+	return err
+}
+
+func (p *postgresStore) Get(ctx context.Context, q Query) (Result, error) {
+	where, args, err := toWhere(q, 1)
+	if err != nil {
+		return nil, err
+	}
+	row := p.querier(ctx).QueryRow(ctx,
+		fmt.Sprintf("SELECT doc FROM %s%s LIMIT 1", postgresTable, where), args...)
+
+	var raw []byte
+	if err := row.Scan(&raw); err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &postgresResult{raw: raw}, nil
+}
+
+// Update replaces the matched document's doc column wholesale, matching
+// mongoStore.Update's ReplaceOne and memoryStore.Update's overwrite: any
+// field present in the old document but absent from val does not
+// survive the call.
+func (p *postgresStore) Update(ctx context.Context, q Query, val interface{}) error {
+	doc, err := json.Marshal(val)
+	if err != nil {
+		return err
+	}
+
+	where, args, err := toWhere(q, 2)
+	if err != nil {
+		return err
+	}
+	args = append([]interface{}{doc}, args...)
+
+	tag, err := p.querier(ctx).Exec(ctx,
+		fmt.Sprintf("UPDATE %s SET doc = $1::jsonb WHERE id = (SELECT id FROM %s%s LIMIT 1)",
+			postgresTable, postgresTable, where), args...)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// UpdatePartial merges val's fields into the matched document's doc
+// column, matching mongoStore.UpdatePartial's $set semantics: fields not
+// present in val are left untouched.
+func (p *postgresStore) UpdatePartial(ctx context.Context, q Query, val interface{}) error {
+	doc, err := json.Marshal(val)
+	if err != nil {
+		return err
+	}
+
+	where, args, err := toWhere(q, 2)
+	if err != nil {
+		return err
+	}
+	args = append([]interface{}{doc}, args...)
+
+	tag, err := p.querier(ctx).Exec(ctx,
+		fmt.Sprintf("UPDATE %s SET doc = doc || $1::jsonb WHERE id = (SELECT id FROM %s%s LIMIT 1)",
+			postgresTable, postgresTable, where), args...)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (p *postgresStore) List(ctx context.Context, q Query, skip, limit int) (Cursor, error) {
+	where, args, err := toWhere(q, 1)
+	if err != nil {
+		return nil, err
+	}
+	query := fmt.Sprintf("SELECT doc FROM %s%s OFFSET %d", postgresTable, where, skip)
+	if limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", limit)
+	}
+
+	rows, err := p.querier(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &postgresCursor{rows: rows}, nil
+}
+
+func (p *postgresStore) EnsureIndices(ctx context.Context, indices []Index) error {
+	for _, ind := range indices {
+		if len(ind.Keys) == 0 {
+			continue
+		}
+
+		exprs := make([]string, len(ind.Keys))
+		for i, k := range ind.Keys {
+			if err := validateIdentifier(k.Key); err != nil {
+				return err
+			}
+
+			order := ""
+			if k.Desc {
+				order = " DESC"
+			}
+			exprs[i] = fmt.Sprintf("(doc->>'%s')%s", k.Key, order)
+		}
+
+		unique := ""
+		if ind.Unique {
+			unique = "UNIQUE "
+		}
+
+		name := ind.Name
+		if name == "" {
+			name = fmt.Sprintf("%s_idx", postgresTable)
+		}
+		if err := validateIdentifier(name); err != nil {
+			return err
+		}
+
+		_, err := p.querier(ctx).Exec(ctx, fmt.Sprintf(
+			"CREATE %sINDEX IF NOT EXISTS %s ON %s (%s)",
+			unique, name, postgresTable, strings.Join(exprs, ", "),
+		))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *postgresStore) SetTTL(ctx context.Context) error {
+	// Postgres has no native TTL index; expiry for this backend is
+	// expected to be enforced by a periodic DELETE job instead.
+	return nil
+}
+
+// Transaction runs fn against a real Postgres transaction: it begins
+// tx on the pool, binds it to a derived context via txKey, and passes
+// that context to fn so every CRUD call fn makes (Create, Get,
+// Update, ...) resolves to tx through querier instead of running
+// directly against the pool and committing outside the transaction.
+func (p *postgresStore) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	txCtx := context.WithValue(ctx, txKey{}, tx)
+
+	if err := fn(txCtx); err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (p *postgresStore) Ping(ctx context.Context) error {
+	return p.pool.Ping(ctx)
+}
+
+func (p *postgresStore) Close() error {
+	p.pool.Close()
+	return nil
+}
@@ -0,0 +1,74 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+)
+
+// RetryPolicy configures how a mongoStore retries operations that fail
+// with ErrTransient (primary failover, unknown transaction commit
+// result, etc). This is the recommended MongoDB transaction pattern:
+// retry the whole callback until it succeeds or fails with a
+// non-transient error.
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Jitter         time.Duration
+}
+
+// DefaultRetryPolicy is a conservative policy suitable for most
+// deployments: a handful of attempts with capped exponential backoff.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: 100 * time.Millisecond,
+	MaxBackoff:     2 * time.Second,
+	Jitter:         50 * time.Millisecond,
+}
+
+func (p RetryPolicy) orDefault() RetryPolicy {
+	if p.MaxAttempts <= 0 {
+		return DefaultRetryPolicy
+	}
+	return p
+}
+
+// retry runs fn, retrying while it returns ErrTransient, for at most
+// MaxAttempts attempts in total with exponential backoff between them. It
+// gives up early if ctx is cancelled while waiting.
+func (p RetryPolicy) retry(ctx context.Context, fn func() error) error {
+	p = p.orDefault()
+	backoff := p.InitialBackoff
+
+	var err error
+	for attempt := 0; attempt < p.MaxAttempts; attempt++ {
+		err = fn()
+		if err == nil || !errors.Is(err, ErrTransient) {
+			return err
+		}
+
+		if attempt == p.MaxAttempts-1 {
+			break
+		}
+
+		wait := backoff
+		if p.Jitter > 0 {
+			wait += time.Duration(rand.Int63n(int64(p.Jitter)))
+		}
+
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		backoff *= 2
+		if p.MaxBackoff > 0 && backoff > p.MaxBackoff {
+			backoff = p.MaxBackoff
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,90 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestRetryPolicyRetriesTransientUntilSuccess(t *testing.T) {
+	p := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+	attempts := 0
+	err := p.retry(context.Background(), func() error {
+		attempts++
+		if attempts < 3 {
+			return ErrTransient
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("retry: %v", err)
+	}
+	if attempts != 3 {
+		t.Fatalf("got %d attempts, want 3", attempts)
+	}
+}
+
+func TestRetryPolicyGivesUpAfterMaxAttempts(t *testing.T) {
+	p := RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+	attempts := 0
+	err := p.retry(context.Background(), func() error {
+		attempts++
+		return ErrTransient
+	})
+	if !errors.Is(err, ErrTransient) {
+		t.Fatalf("got err %v, want ErrTransient", err)
+	}
+	if attempts != 2 {
+		t.Fatalf("got %d attempts, want 2", attempts)
+	}
+}
+
+func TestRetryPolicyDoesNotRetryNonTransient(t *testing.T) {
+	p := RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+	attempts := 0
+	wantErr := errors.New("permanent")
+	err := p.retry(context.Background(), func() error {
+		attempts++
+		return wantErr
+	})
+	if err != wantErr {
+		t.Fatalf("got err %v, want %v", err, wantErr)
+	}
+	if attempts != 1 {
+		t.Fatalf("got %d attempts, want 1", attempts)
+	}
+}
+
+func TestRetryPolicyStopsOnContextCancel(t *testing.T) {
+	p := RetryPolicy{MaxAttempts: 5, InitialBackoff: 50 * time.Millisecond, MaxBackoff: 50 * time.Millisecond}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	attempts := 0
+	err := p.retry(ctx, func() error {
+		attempts++
+		return ErrTransient
+	})
+	if err != context.Canceled {
+		t.Fatalf("got err %v, want context.Canceled", err)
+	}
+	if attempts != 1 {
+		t.Fatalf("got %d attempts, want 1", attempts)
+	}
+}
+
+func TestClassifyMapsNotFound(t *testing.T) {
+	if got := classify(nil); got != nil {
+		t.Fatalf("got %v, want nil", got)
+	}
+	if got := classify(mongo.ErrNoDocuments); got != ErrNotFound {
+		t.Fatalf("got %v, want ErrNotFound", got)
+	}
+}
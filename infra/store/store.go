@@ -0,0 +1,92 @@
+// Package store defines a backend-neutral persistence contract for
+// Cogman's task history, so the rest of the codebase does not depend
+// directly on MongoDB (or any other engine).
+package store
+
+import "context"
+
+// Op is a comparison operator used in a Query predicate.
+type Op string
+
+// Supported predicate operators.
+const (
+	OpEq  Op = "eq"
+	OpNe  Op = "ne"
+	OpGt  Op = "gt"
+	OpGte Op = "gte"
+	OpLt  Op = "lt"
+	OpLte Op = "lte"
+	OpIn  Op = "in"
+)
+
+// Predicate is a single field/operator/value condition.
+type Predicate struct {
+	Field string
+	Op    Op
+	Value interface{}
+}
+
+// Query is a set of predicates ANDed together. It lets callers express
+// filters without depending on a backend-specific query language such
+// as bson.M or SQL.
+type Query []Predicate
+
+// Eq is a shorthand constructor for an equality predicate.
+func Eq(field string, value interface{}) Predicate {
+	return Predicate{Field: field, Op: OpEq, Value: value}
+}
+
+// Result holds a single record returned by Get.
+type Result interface {
+	Decode(v interface{}) error
+}
+
+// Cursor iterates over a result set returned by List.
+type Cursor interface {
+	Next() bool
+	Decode(v interface{}) error
+	Close() error
+	Err() error
+}
+
+// IndexKey is a single field in an Index.
+type IndexKey struct {
+	Key  string
+	Desc bool
+}
+
+// Index describes a store index independent of the backing engine.
+type Index struct {
+	Keys   []IndexKey
+	Name   string
+	Unique bool
+	Sparse bool
+}
+
+// TaskStore is the persistence contract the Cogman task-history layer
+// depends on. Implementations back it with MongoDB, Postgres, or an
+// in-memory map for tests. Every operation takes a context so a
+// multi-tenant caller can carry routing information (e.g. which
+// collection/schema to use) down to the backend.
+type TaskStore interface {
+	Create(ctx context.Context, t interface{}) error
+	Get(ctx context.Context, q Query) (Result, error)
+	// Update replaces the matched document wholesale: fields present in
+	// the old document but absent from val do not survive the call.
+	Update(ctx context.Context, q Query, val interface{}) error
+	// UpdatePartial merges val's fields into the matched document,
+	// leaving fields not present in val untouched. val must be a flat
+	// field map or struct (e.g. map[string]interface{}{"status": "done"}),
+	// never a backend-native update document (no $set, no "doc ||" —
+	// every implementation applies its own translation).
+	UpdatePartial(ctx context.Context, q Query, val interface{}) error
+	List(ctx context.Context, q Query, skip, limit int) (Cursor, error)
+	EnsureIndices(ctx context.Context, indices []Index) error
+	SetTTL(ctx context.Context) error
+	// Transaction runs fn inside a backend transaction/session, scoped
+	// to ctx. fn receives a derived context that carries the session so
+	// operations inside it participate in the same transaction.
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+	Ping(ctx context.Context) error
+	Close() error
+}
@@ -0,0 +1,28 @@
+package infra
+
+import (
+	"context"
+
+	"github.com/Kimbbakar/Cogman/infra/store"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// watcher is implemented by TaskStore backends that support change
+// streams (currently only the Mongo store).
+type watcher interface {
+	Watch(ctx context.Context, pipeline []bson.D, resumeToken bson.Raw) (<-chan store.TaskEvent, error)
+}
+
+// Watch subscribes to every change (insert, update, delete, ...) of the
+// task documents as a push stream, an alternative to polling task
+// status. Nothing in this package delivers webhooks or records metrics
+// from the stream. It returns ErrWatchUnsupported if ts isn't backed by
+// a store that supports change streams (e.g. Postgres or the in-memory
+// store used in tests).
+func Watch(ctx context.Context, ts store.TaskStore, pipeline []bson.D, resumeToken bson.Raw) (<-chan store.TaskEvent, error) {
+	w, ok := ts.(watcher)
+	if !ok {
+		return nil, ErrWatchUnsupported
+	}
+	return w.Watch(ctx, pipeline, resumeToken)
+}